@@ -0,0 +1,307 @@
+package observer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BackpressurePolicy selects what a channel subscriber's ring buffer does
+// when NotifyAsync outruns it.
+type BackpressurePolicy int
+
+const (
+	// DropOldest evicts the oldest buffered event to make room for the
+	// newest one. This is the default: publishers never block and slow
+	// subscribers simply lose history.
+	DropOldest BackpressurePolicy = iota
+	// BlockPublisher makes the publishing goroutine wait for room in the
+	// ring buffer instead of dropping anything.
+	BlockPublisher
+	// UnsubscribeOnOverflow drops the subscriber (closing its channel) the
+	// first time its buffer fills, instead of silently dropping events or
+	// stalling the publisher.
+	UnsubscribeOnOverflow
+)
+
+// Event is a single occurrence delivered to a channel subscriber created via
+// Dispatcher.SubscribeChan. Seq comes from one counter per Dispatcher, shared
+// by every event and model type and by the per-handler rings of
+// dispatchEventAsync, so it increases monotonically for each subscriber but
+// is not contiguous: a gap does not by itself mean events were dropped.
+type Event struct {
+	Seq   uint64
+	Event ObserverEventType
+	Model any
+	At    time.Time
+}
+
+// ringSubscriber is a bounded, single-consumer event queue backing one
+// SubscribeChan call. Entries older than ttl are pruned by a background
+// goroutine even if the consumer never drains them.
+type ringSubscriber struct {
+	key    typeEventKey
+	policy BackpressurePolicy
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	items  []Event
+	cap    int
+	closed bool
+	wake   chan struct{}
+
+	out    chan Event
+	cancel chan struct{}
+	once   sync.Once
+}
+
+func newRingSubscriber(key typeEventKey, bufSize int, ttl time.Duration, policy BackpressurePolicy) *ringSubscriber {
+	if bufSize <= 0 {
+		bufSize = 1
+	}
+	return &ringSubscriber{
+		key:    key,
+		policy: policy,
+		ttl:    ttl,
+		items:  make([]Event, 0, bufSize),
+		cap:    bufSize,
+		wake:   make(chan struct{}, 1),
+		out:    make(chan Event),
+		cancel: make(chan struct{}),
+	}
+}
+
+func (rs *ringSubscriber) nudge() {
+	select {
+	case rs.wake <- struct{}{}:
+	default:
+	}
+}
+
+// push appends ev, applying the subscriber's BackpressurePolicy if the ring
+// is full. It returns false once the subscriber has been unsubscribed.
+func (rs *ringSubscriber) push(ev Event) bool {
+	rs.mu.Lock()
+	if rs.closed {
+		rs.mu.Unlock()
+		return false
+	}
+
+	for len(rs.items) >= rs.cap {
+		switch rs.policy {
+		case DropOldest:
+			rs.items = rs.items[1:]
+		case UnsubscribeOnOverflow:
+			rs.closed = true
+			rs.mu.Unlock()
+			rs.Cancel()
+			return false
+		case BlockPublisher:
+			rs.mu.Unlock()
+			time.Sleep(time.Millisecond)
+			rs.mu.Lock()
+			if rs.closed {
+				rs.mu.Unlock()
+				return false
+			}
+			continue
+		}
+		break
+	}
+
+	rs.items = append(rs.items, ev)
+	rs.mu.Unlock()
+	rs.nudge()
+	return true
+}
+
+// gcExpired drops buffered entries older than ttl from the head of the ring.
+func (rs *ringSubscriber) gcExpired() {
+	if rs.ttl <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-rs.ttl)
+	rs.mu.Lock()
+	i := 0
+	for i < len(rs.items) && rs.items[i].At.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		rs.items = rs.items[i:]
+	}
+	rs.mu.Unlock()
+}
+
+// run delivers buffered events to out until Cancel is called.
+func (rs *ringSubscriber) run() {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	defer close(rs.out)
+
+	for {
+		rs.mu.Lock()
+		for len(rs.items) > 0 {
+			ev := rs.items[0]
+			rs.items = rs.items[1:]
+			rs.mu.Unlock()
+
+		send:
+			for {
+				select {
+				case rs.out <- ev:
+					break send
+				case <-ticker.C:
+					// Keep pruning while the consumer is not reading, and
+					// drop ev itself once it is older than ttl.
+					rs.gcExpired()
+					if rs.ttl > 0 && time.Since(ev.At) > rs.ttl {
+						break send
+					}
+				case <-rs.cancel:
+					return
+				}
+			}
+
+			rs.mu.Lock()
+		}
+		closed := rs.closed
+		rs.mu.Unlock()
+		if closed {
+			return
+		}
+
+		select {
+		case <-rs.wake:
+		case <-ticker.C:
+			rs.gcExpired()
+		case <-rs.cancel:
+			return
+		}
+	}
+}
+
+// Cancel stops delivery and releases the subscriber; later pushes return
+// false. Safe to call more than once.
+func (rs *ringSubscriber) Cancel() {
+	rs.once.Do(func() {
+		rs.mu.Lock()
+		rs.closed = true
+		rs.mu.Unlock()
+		close(rs.cancel)
+	})
+}
+
+// isClosed reports whether rs has been unsubscribed, either by Cancel or by
+// UnsubscribeOnOverflow in push.
+func (rs *ringSubscriber) isClosed() bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.closed
+}
+
+// SubscribeChan returns a channel fed by a bounded ring buffer of events
+// published for model/event via NotifyAsync, plus a cancel func that stops
+// delivery and releases the subscriber. Use Dispatcher.ConfigureBackpressure
+// to change the default drop/block/unsubscribe policy and TTL applied to
+// buffers created here; the default is DropOldest with no TTL.
+func (d *Dispatcher) SubscribeChan(model any, event ObserverEventType, bufSize int) (<-chan Event, func()) {
+	key := typeEventKey{payloadType: normalizeModelType(model), event: event}
+
+	d.mu.RLock()
+	policy, ttl := d.bufferPolicy, d.bufferTTL
+	d.mu.RUnlock()
+
+	rs := newRingSubscriber(key, bufSize, ttl, policy)
+
+	d.mu.Lock()
+	if d.chanSubs == nil {
+		d.chanSubs = make(map[typeEventKey][]*ringSubscriber)
+	}
+	d.chanSubs[key] = append(d.chanSubs[key], rs)
+	d.mu.Unlock()
+
+	go rs.run()
+
+	return rs.out, func() {
+		rs.Cancel()
+		d.mu.Lock()
+		subs := d.chanSubs[key]
+		for i, s := range subs {
+			if s == rs {
+				d.chanSubs[key] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// ConfigureBackpressure sets the BackpressurePolicy and TTL applied to ring
+// buffers created by future SubscribeChan calls on d, and to the per-handler
+// rings dispatchEventAsync creates from then on (see asyncHandlerRing).
+func (d *Dispatcher) ConfigureBackpressure(policy BackpressurePolicy, ttl time.Duration) {
+	d.mu.Lock()
+	d.bufferPolicy = policy
+	d.bufferTTL = ttl
+	d.mu.Unlock()
+}
+
+// publishToChanSubs fans ev out to every channel subscriber registered for
+// key, applying each subscriber's backpressure policy rather than spawning
+// an unbounded goroutine per subscriber.
+func (d *Dispatcher) publishToChanSubs(key typeEventKey, model any) {
+	d.mu.RLock()
+	subs := d.chanSubs[key]
+	d.mu.RUnlock()
+	if len(subs) == 0 {
+		return
+	}
+
+	ev := Event{
+		Seq:   atomic.AddUint64(&d.seq, 1),
+		Event: key.event,
+		Model: model,
+		At:    time.Now(),
+	}
+	for _, rs := range subs {
+		rs.push(ev)
+	}
+}
+
+// defaultAsyncHandlerBufSize bounds the ring buffer dispatchEventAsync gives
+// each Observer()/Attach/Subscribe[T]-registered handler, same as
+// SubscribeChan would require a caller to pick explicitly.
+const defaultAsyncHandlerBufSize = 256
+
+// asyncHandlerRing returns the bounded ring feeding h's dedicated worker
+// goroutine, creating both lazily on first use. Unlike a SubscribeChan
+// consumer, nothing ranges over rs.out from outside the package — a
+// second goroutine started here does, invoking h through d.invoke for
+// every event the ring yields.
+//
+// A cached ring can have unsubscribed itself (UnsubscribeOnOverflow sets
+// closed in push); reusing it would silently and permanently drop every
+// future event for h, so that case gets a fresh ring instead.
+func (d *Dispatcher) asyncHandlerRing(key typeEventKey, h *genericHandler) *ringSubscriber {
+	d.mu.Lock()
+	if rs, ok := d.asyncWorkers[h]; ok && !rs.isClosed() {
+		d.mu.Unlock()
+		return rs
+	}
+	if d.asyncWorkers == nil {
+		d.asyncWorkers = make(map[*genericHandler]*ringSubscriber)
+	}
+	rs := newRingSubscriber(key, defaultAsyncHandlerBufSize, d.bufferTTL, d.bufferPolicy)
+	d.asyncWorkers[h] = rs
+	d.mu.Unlock()
+
+	go rs.run()
+	go func() {
+		for ev := range rs.out {
+			_ = d.invoke(context.Background(), ev.Event, ev.Model, h)
+		}
+	}()
+
+	return rs
+}
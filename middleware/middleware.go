@@ -0,0 +1,53 @@
+// Package middleware provides ready-made observer.Middleware
+// implementations for the cross-cutting concerns every non-trivial user of
+// Dispatcher.Use eventually needs: panic recovery, timeouts, tracing, and
+// metrics.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-extreme/observer"
+)
+
+// Recover wraps next so a panicking handler is converted into an error
+// instead of taking down the goroutine it ran on. Register it first (as the
+// outermost middleware) so it can catch panics from every other middleware
+// in the chain too.
+func Recover() observer.Middleware {
+	return func(next observer.HandlerFunc) observer.HandlerFunc {
+		return func(ctx context.Context, event observer.ObserverEventType, model any) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("observer: recovered panic in %s handler: %v", event, r)
+				}
+			}()
+			return next(ctx, event, model)
+		}
+	}
+}
+
+// Timeout aborts a handler invocation with ctx's error once d elapses.
+// next keeps running on its goroutine after the timeout fires; it is the
+// handler's responsibility to honor ctx cancellation if it needs to stop
+// early.
+func Timeout(d time.Duration) observer.Middleware {
+	return func(next observer.HandlerFunc) observer.HandlerFunc {
+		return func(ctx context.Context, event observer.ObserverEventType, model any) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			done := make(chan error, 1)
+			go func() { done <- next(ctx, event, model) }()
+
+			select {
+			case err := <-done:
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
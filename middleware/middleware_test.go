@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/go-extreme/observer"
+)
+
+func TestRecoverConvertsPanicToError(t *testing.T) {
+	h := Recover()(func(ctx context.Context, event observer.ObserverEventType, model any) error {
+		panic("kaboom")
+	})
+
+	if err := h(context.Background(), "boom", nil); err == nil {
+		t.Error("Expected Recover to convert the panic into an error, got nil")
+	}
+}
+
+func TestTimeoutAbortsSlowHandler(t *testing.T) {
+	h := Timeout(10 * time.Millisecond)(func(ctx context.Context, event observer.ObserverEventType, model any) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := h(context.Background(), "slow", nil)
+	if err == nil {
+		t.Error("Expected Timeout to abort the slow handler with an error, got nil")
+	}
+}
+
+func TestPrometheusReusesCollectorsOnSameRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Expected a second Prometheus() call on the same registry not to panic, got %v", r)
+		}
+	}()
+
+	Prometheus(reg)
+	Prometheus(reg)
+}
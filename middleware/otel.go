@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-extreme/observer"
+)
+
+// OTel wraps next in a span (named after the event) from tracer, recording
+// any returned error on the span before ending it.
+func OTel(tracer trace.Tracer) observer.Middleware {
+	return func(next observer.HandlerFunc) observer.HandlerFunc {
+		return func(ctx context.Context, event observer.ObserverEventType, model any) error {
+			ctx, span := tracer.Start(ctx, string(event))
+			defer span.End()
+
+			if err := next(ctx, event, model); err != nil {
+				span.RecordError(err)
+				return err
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/go-extreme/observer"
+)
+
+// Prometheus registers observer_events_total and
+// observer_dispatch_duration_seconds on reg and returns a Middleware that
+// records every handler invocation against them. If reg already has
+// collectors under those names — e.g. Prometheus was called before for
+// another Dispatcher sharing the same registry — it reuses them instead
+// of panicking on duplicate registration.
+func Prometheus(reg prometheus.Registerer) observer.Middleware {
+	eventsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "observer_events_total",
+		Help: "Total observer handler invocations, labeled by event and outcome.",
+	}, []string{"event", "outcome"})
+	if err := reg.Register(eventsTotal); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			eventsTotal = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			panic(err)
+		}
+	}
+
+	dispatchDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "observer_dispatch_duration_seconds",
+		Help: "Duration of observer handler invocations, labeled by event.",
+	}, []string{"event"})
+	if err := reg.Register(dispatchDuration); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			dispatchDuration = are.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			panic(err)
+		}
+	}
+
+	return func(next observer.HandlerFunc) observer.HandlerFunc {
+		return func(ctx context.Context, event observer.ObserverEventType, model any) error {
+			start := time.Now()
+			err := next(ctx, event, model)
+
+			outcome := "ok"
+			if err != nil {
+				outcome = "error"
+			}
+			eventsTotal.WithLabelValues(string(event), outcome).Inc()
+			dispatchDuration.WithLabelValues(string(event)).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package observer
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"reflect"1
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+var ctxType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // ObserverEventType represents the event type (e.g. BeforeCreate, AfterDelete).
 type ObserverEventType string
 
@@ -74,17 +83,132 @@ func init() {
 	}
 }
 
+// typeEventKey indexes handlers by the payload type they accept and the
+// event they fire on, so Publish/dispatchEvent can look them up without
+// any MethodByName reflection on the hot path.
+type typeEventKey struct {
+	payloadType reflect.Type
+	event       ObserverEventType
+}
+
+// genericHandler wraps a typed handler (from Subscribe or a discovered
+// Observer method) as a func(any) so it can live in Dispatcher.handlers
+// alongside handlers of other payload types. ctxFn is set only for
+// handlers that know how to honor a context.Context and report an error;
+// call() falls back to fn for everything else.
+//
+// priority and wantsEvent only matter to AttachWithPriority handlers:
+// priority controls run order (ascending, see addHandler) and wantsEvent
+// marks a handler whose method takes a *LifecycleEvent instead of the raw
+// model, so dispatchAbortable knows to pass it one.
+type genericHandler struct {
+	fn         func(any)
+	ctxFn      func(ctx context.Context, a any) error
+	priority   int
+	wantsEvent bool
+}
+
+// call invokes the handler under ctx, returning its error if it supports
+// one. Handlers without a ctxFn (e.g. Subscribe[T] handlers) just run fn.
+func (h *genericHandler) call(ctx context.Context, a any) error {
+	if h.ctxFn != nil {
+		return h.ctxFn(ctx, a)
+	}
+	h.fn(a)
+	return nil
+}
+
 type Dispatcher struct {
-	mu        sync.RWMutex
-	observers map[reflect.Type][]any
+	// mu serializes writers (registerModel, Attach, addHandler, ...). Handler
+	// lookups on the dispatch path never take it — they load handlersPtr
+	// atomically instead; see loadHandlers. Dispatch still takes mu
+	// elsewhere: chain read-locks it for every handler invocation, and
+	// dispatchEventAsync locks it in asyncHandlerRing and read-locks it in
+	// publishToChanSubs.
+	mu          sync.RWMutex
+	observers   map[reflect.Type][]any
+	handlersPtr atomic.Pointer[map[typeEventKey][]*genericHandler]
+
+	// Ring-buffered async subscribers; see buffered_dispatcher.go.
+	seq          uint64
+	chanSubs     map[typeEventKey][]*ringSubscriber
+	bufferPolicy BackpressurePolicy
+	bufferTTL    time.Duration
+
+	// asyncWorkers gives each handler dispatchEventAsync drives its own
+	// bounded ring + persistent worker goroutine, instead of a fresh `go`
+	// per handler per event; see asyncHandlerRing.
+	asyncWorkers map[*genericHandler]*ringSubscriber
+
+	// Wildcard/hierarchical pattern subscribers; see pattern_dispatcher.go.
+	patternMu   sync.RWMutex
+	patternRoot *patternNode
+
+	// Middleware chain wrapped around every handler invocation; see Use.
+	middleware []Middleware
+}
+
+// HandlerFunc is the signature every observer invocation is normalized to
+// before running through the Dispatcher's middleware chain.
+type HandlerFunc func(ctx context.Context, event ObserverEventType, model any) error
+
+// Middleware wraps a HandlerFunc with cross-cutting behavior — panic
+// recovery, timeouts, tracing, metrics, rate limiting, and the like. See
+// the middleware subpackage for ready-made ones.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Use appends mw to d's middleware chain. Middleware runs in the order
+// registered: the first Use call becomes the outermost wrapper, so it sees
+// a handler invocation (and its error) before/after every later one.
+func (d *Dispatcher) Use(mw ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.middleware = append(d.middleware, mw...)
+}
+
+// chain composes d's middleware around next, outermost-first.
+func (d *Dispatcher) chain(next HandlerFunc) HandlerFunc {
+	d.mu.RLock()
+	mws := d.middleware
+	d.mu.RUnlock()
+
+	for i := len(mws) - 1; i >= 0; i-- {
+		next = mws[i](next)
+	}
+	return next
+}
+
+// invoke runs a single handler through d's middleware chain. Every
+// dispatchEvent/dispatchEventAsync/dispatchEventContext call routes through
+// here instead of calling the handler directly, so panic recovery, timeouts,
+// tracing, and metrics middleware apply uniformly regardless of which
+// Notify variant triggered the handler.
+func (d *Dispatcher) invoke(ctx context.Context, event ObserverEventType, model any, h *genericHandler) error {
+	final := func(ctx context.Context, event ObserverEventType, model any) error {
+		return h.call(ctx, model)
+	}
+	return d.chain(final)(ctx, event, model)
 }
 
 var globalDispatcher = NewDispatcher()
 
 func NewDispatcher() *Dispatcher {
-	return &Dispatcher{
+	d := &Dispatcher{
 		observers: make(map[reflect.Type][]any),
 	}
+	empty := make(map[typeEventKey][]*genericHandler)
+	d.handlersPtr.Store(&empty)
+	return d
+}
+
+// loadHandlers returns the current handler map with a single atomic pointer
+// load — no mutex, so it never blocks behind a concurrent writer.
+func (d *Dispatcher) loadHandlers() map[typeEventKey][]*genericHandler {
+	m := d.handlersPtr.Load()
+	if m == nil {
+		return nil
+	}
+	return *m
 }
 
 // SetDebug enables or disables debug logging dynamically
@@ -124,42 +248,240 @@ func (d *Dispatcher) registerModel(model any) {
 		// ✅ If observers already registered for this model, skip
 		if _, exists := d.observers[modelType]; exists {
 			debugPrintf("⚠️ %s already registered, skipping duplicate registration, dont worry the observer will handle duplicate registrations\n", modelType.Name())
+			d.mu.Unlock()
 		} else {
 			observers := obsModel.Observer()
 			d.observers[modelType] = append(d.observers[modelType], observers...)
 			debugPrintf("✅ %d observers registered for %s\n", len(observers), modelType.Name())
+			d.mu.Unlock()
+
+			for _, obs := range observers {
+				d.indexObserver(modelType, obs)
+			}
 		}
-		d.mu.Unlock()
 
 	} else {
 		debugPrintf("⚠️ %s does NOT implement Observables\n", modelType.Name())
 	}
 }
 
+// indexObserver translates the lifecycle methods a legacy Observer()
+// instance exposes (discovered via MethodByName against every known
+// event type) into generic handlers, so dispatchEvent/dispatchEventAsync
+// can serve them from Dispatcher.handlers instead of reflecting on every
+// call.
+func (d *Dispatcher) indexObserver(modelType reflect.Type, obs any) {
+	for _, event := range ListRegisteredEvents() {
+		method := reflect.ValueOf(obs).MethodByName(string(event))
+		if !method.IsValid() {
+			continue
+		}
+		m := method // capture
+		d.addHandler(typeEventKey{payloadType: modelType, event: event}, &genericHandler{
+			fn:    func(a any) { _ = callObserverMethod(context.Background(), m, a) },
+			ctxFn: func(ctx context.Context, a any) error { return callObserverMethod(ctx, m, a) },
+		})
+	}
+}
+
+// addHandler appends h to key's handler list, keeping it sorted ascending
+// by priority (stable, so same-priority handlers keep registration order).
+// Handlers registered outside AttachWithPriority default to priority 0.
+//
+// Writers clone the whole map and swap it in under mu, copy-on-write style,
+// so readers (loadHandlers) never contend with a writer or each other.
+func (d *Dispatcher) addHandler(key typeEventKey, h *genericHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old := d.loadHandlers()
+	next := make(map[typeEventKey][]*genericHandler, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+
+	existing := next[key]
+	merged := make([]*genericHandler, len(existing), len(existing)+1)
+	copy(merged, existing)
+	merged = append(merged, h)
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].priority < merged[j].priority })
+	next[key] = merged
+
+	d.handlersPtr.Store(&next)
+}
+
+func (d *Dispatcher) removeHandler(key typeEventKey, h *genericHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old := d.loadHandlers()
+	next := make(map[typeEventKey][]*genericHandler, len(old))
+	for k, v := range old {
+		next[k] = v
+	}
+
+	existing := next[key]
+	filtered := make([]*genericHandler, 0, len(existing))
+	for _, e := range existing {
+		if e != h {
+			filtered = append(filtered, e)
+		}
+	}
+	next[key] = filtered
+
+	d.handlersPtr.Store(&next)
+
+	// h can no longer be reached through handlersPtr, so its dedicated
+	// asyncHandlerRing worker (if dispatchEventAsync ever started one) has
+	// nothing left to drive — cancel it instead of leaking its two
+	// goroutines and GC ticker for the life of the dispatcher.
+	if rs, ok := d.asyncWorkers[h]; ok {
+		rs.Cancel()
+		delete(d.asyncWorkers, h)
+	}
+}
+
 // ✅ Notify sync event
-func (d *Dispatcher) dispatchEvent(event ObserverEventType, model any) {
+//
+// Before*-prefixed events dispatch through dispatchAbortable instead of the
+// fire-and-forget loop below: handlers run in ascending priority order and
+// the first one to error or veto via LifecycleEvent.SetAborted stops the
+// rest, with its error returned to the caller.
+func (d *Dispatcher) dispatchEvent(event ObserverEventType, model any) error {
 	modelType := normalizeModelType(model)
 	debugPrintf("🚀 Dispatching SYNC event '%s' for %s\n", event, modelType.Name())
 
-	d.mu.RLock()
-	observers, ok := d.observers[modelType]
-	d.mu.RUnlock()
+	handlers := d.loadHandlers()[typeEventKey{payloadType: modelType, event: event}]
 
-	if !ok {
+	var err error
+	if len(handlers) == 0 {
 		debugPrintf("⚠️ No observers for %s\n", modelType.Name())
-		return
-	}
-
-	var wg sync.WaitGroup
-	for _, obs := range observers {
-		method := reflect.ValueOf(obs).MethodByName(string(event))
-		if method.IsValid() {
+	} else if isAbortableEvent(event) {
+		err = d.dispatchAbortable(event, model, handlers)
+	} else {
+		var wg sync.WaitGroup
+		for _, h := range handlers {
 			wg.Add(1)
-			callObserverMethod(method, model)
+			_ = d.invoke(context.Background(), event, model, h)
 			wg.Done()
 		}
+		wg.Wait()
 	}
-	wg.Wait()
+
+	// Dot-separated event names (e.g. "user.profile.updated") also reach
+	// any AttachPattern subscribers whose pattern matches — but not if a
+	// higher-priority handler above vetoed the transition.
+	if err == nil {
+		d.dispatchPattern(event, model)
+	}
+	return err
+}
+
+// isAbortableEvent reports whether event is a "Before*" lifecycle event,
+// the only ones dispatchEvent runs through dispatchAbortable.
+func isAbortableEvent(event ObserverEventType) bool {
+	return strings.HasPrefix(string(event), "Before")
+}
+
+// dispatchAbortable runs handlers (already priority-sorted by addHandler)
+// one at a time, stopping at the first one that errors or calls
+// LifecycleEvent.SetAborted. Handlers registered with AttachWithPriority
+// whose method takes a *LifecycleEvent get one wrapping model instead of
+// the raw model, so they can inspect or veto what earlier handlers decided.
+func (d *Dispatcher) dispatchAbortable(event ObserverEventType, model any, handlers []*genericHandler) error {
+	le := newLifecycleEvent(model)
+
+	for _, h := range handlers {
+		arg := any(model)
+		if h.wantsEvent {
+			arg = le
+		}
+
+		if err := d.invoke(context.Background(), event, arg, h); err != nil {
+			return err
+		}
+		if le.IsAborted() {
+			return fmt.Errorf("observer: %s aborted by an observer", event)
+		}
+	}
+	return nil
+}
+
+// LifecycleEvent wraps the model passed to an abortable "Before*" event so
+// an observer can veto the transition for every observer scheduled after
+// it, modeled on the "aborted event" pattern common to PHP/Go event
+// managers. Observers opt in by taking a *LifecycleEvent instead of the raw
+// model type in their handler method.
+type LifecycleEvent struct {
+	mu      sync.Mutex
+	data    any
+	aborted bool
+}
+
+func newLifecycleEvent(data any) *LifecycleEvent {
+	return &LifecycleEvent{data: data}
+}
+
+// SetAborted vetoes the in-flight lifecycle transition, stopping any
+// observer scheduled after the caller from running.
+func (e *LifecycleEvent) SetAborted() {
+	e.mu.Lock()
+	e.aborted = true
+	e.mu.Unlock()
+}
+
+// IsAborted reports whether a prior observer already vetoed this event.
+func (e *LifecycleEvent) IsAborted() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.aborted
+}
+
+// Data returns the model the event was dispatched for.
+func (e *LifecycleEvent) Data() any {
+	return e.data
+}
+
+// AttachWithPriority registers observer's event method like Attach, but
+// runs it in ascending priority order relative to every other handler for
+// (model, event) — lower priority values run first. If event has a
+// "Before" prefix, the handler also participates in abort semantics: it
+// may return an error, or take a *LifecycleEvent and call SetAborted, to
+// veto the transition and stop any handler scheduled after it. Handlers
+// added via Attach/Register default to priority 0 and run in registration
+// order relative to each other.
+func (d *Dispatcher) AttachWithPriority(model any, event ObserverEventType, observer any, priority int) {
+	modelType := normalizeModelType(model)
+
+	method := reflect.ValueOf(observer).MethodByName(string(event))
+	if !method.IsValid() {
+		debugPrintf("⚠️ %T has no %s method, AttachWithPriority is a no-op\n", observer, event)
+		return
+	}
+
+	d.mu.Lock()
+	d.observers[modelType] = append(d.observers[modelType], observer)
+	d.mu.Unlock()
+
+	d.addHandler(typeEventKey{payloadType: modelType, event: event}, &genericHandler{
+		priority:   priority,
+		wantsEvent: methodWantsLifecycleEvent(method),
+		fn:         func(a any) { _ = callObserverMethod(context.Background(), method, a) },
+		ctxFn:      func(ctx context.Context, a any) error { return callObserverMethod(ctx, method, a) },
+	})
+}
+
+var lifecycleEventType = reflect.TypeOf((*LifecycleEvent)(nil))
+
+// methodWantsLifecycleEvent reports whether method's model parameter (the
+// one after an optional leading context.Context) is a *LifecycleEvent.
+func methodWantsLifecycleEvent(method reflect.Value) bool {
+	mt := method.Type()
+	idx := 0
+	if mt.NumIn() > 0 && mt.In(0) == ctxType {
+		idx = 1
+	}
+	return mt.NumIn() > idx && mt.In(idx) == lifecycleEventType
 }
 
 // ✅ Notify async event (non-blocking)
@@ -167,35 +489,112 @@ func (d *Dispatcher) dispatchEventAsync(event ObserverEventType, model any) {
 	modelType := normalizeModelType(model)
 	debugPrintf("🚀 Dispatching ASYNC event '%s' for %s\n", event, modelType.Name())
 
-	d.mu.RLock()
-	observers, ok := d.observers[modelType]
-	d.mu.RUnlock()
+	key := typeEventKey{payloadType: modelType, event: event}
+
+	handlers := d.loadHandlers()[key]
+
+	// Each handler gets its own bounded ring and a persistent worker
+	// goroutine (see asyncHandlerRing) instead of a fresh `go` per handler
+	// per event, so a burst of NotifyAsync calls can't spawn an unbounded
+	// number of goroutines.
+	for _, h := range handlers {
+		d.asyncHandlerRing(key, h).push(Event{
+			Seq:   atomic.AddUint64(&d.seq, 1),
+			Event: event,
+			Model: model,
+			At:    time.Now(),
+		})
+	}
+
+	// Ring-buffered channel subscribers (SubscribeChan) get the same
+	// bounded, backpressure-aware delivery.
+	d.publishToChanSubs(key, model)
 
-	if !ok {
+	if len(handlers) == 0 {
 		debugPrintf("⚠️ No observers for %s\n", modelType.Name())
-		return
 	}
+}
 
-	for _, obs := range observers {
-		method := reflect.ValueOf(obs).MethodByName(string(event))
-		if method.IsValid() {
-			go callObserverMethod(method, model)
+// callObserverMethod invokes an observer method found via MethodByName.
+// Besides the legacy func(T) shape, it also supports func(context.Context, T) error:
+// if the method's first in-parameter is a context.Context, ctx is passed
+// through, and if its return value is an error, that error is propagated
+// back to the caller.
+func callObserverMethod(ctx context.Context, method reflect.Value, model any) error {
+	mt := method.Type()
+
+	paramIdx := 0
+	args := make([]reflect.Value, 0, 2)
+	if mt.NumIn() > 0 && mt.In(0) == ctxType {
+		if ctx == nil {
+			ctx = context.Background()
 		}
+		args = append(args, reflect.ValueOf(ctx))
+		paramIdx = 1
 	}
-}
 
-func callObserverMethod(method reflect.Value, model any) {
 	arg := reflect.ValueOf(model)
-	if arg.Type() != method.Type().In(0) {
-		if arg.Type().Kind() == reflect.Ptr && method.Type().In(0).Kind() != reflect.Ptr {
+	paramType := mt.In(paramIdx)
+	if arg.Type() != paramType {
+		if arg.Type().Kind() == reflect.Ptr && paramType.Kind() != reflect.Ptr {
 			arg = arg.Elem() // convert *User → User
-		} else if method.Type().In(0).Kind() == reflect.Ptr && arg.Type().Kind() != reflect.Ptr {
+		} else if paramType.Kind() == reflect.Ptr && arg.Type().Kind() != reflect.Ptr {
 			ptr := reflect.New(arg.Type())
 			ptr.Elem().Set(arg)
 			arg = ptr
 		}
 	}
-	method.Call([]reflect.Value{arg})
+	args = append(args, arg)
+
+	out := method.Call(args)
+	if mt.NumOut() == 1 && mt.Out(0) == errType && !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+	return nil
+}
+
+// ✅ Notify sync event, context-aware and cancellable
+//
+// NotifyContext runs handlers in parallel (unlike dispatchEvent's serial
+// loop), stops scheduling further handlers once ctx is done, and joins
+// every handler error into a single error via errors.Join. Observers that
+// want to participate implement func(ctx context.Context, m T) error
+// instead of the plain func(T) shape; plain handlers still run, just
+// without a way to veto.
+func (d *Dispatcher) dispatchEventContext(ctx context.Context, event ObserverEventType, model any) error {
+	modelType := normalizeModelType(model)
+	debugPrintf("🚀 Dispatching CTX event '%s' for %s\n", event, modelType.Name())
+
+	handlers := d.loadHandlers()[typeEventKey{payloadType: modelType, event: event}]
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, h := range handlers {
+		if ctx.Err() != nil {
+			mu.Lock()
+			errs = append(errs, ctx.Err())
+			mu.Unlock()
+			break
+		}
+
+		h := h
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.invoke(ctx, event, model, h); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
 }
 
 func normalizeModelType(model any) reflect.Type {
@@ -239,22 +638,61 @@ func (d *Dispatcher) Attach(model any, observer any) {
 	modelType := normalizeModelType(model)
 
 	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	// check if the observer is already attached
 	existing := d.observers[modelType]
 	for _, obs := range existing {
 		if reflect.TypeOf(obs) == reflect.TypeOf(observer) {
 			debugPrintf("⚠️ Observer %T already attached to %s, skipping duplicate\n", observer, modelType.Name())
+			d.mu.Unlock()
 			return
 		}
 	}
 
 	// attach the observer
 	d.observers[modelType] = append(d.observers[modelType], observer)
+	d.mu.Unlock()
+
+	// indexObserver takes d.mu itself (via addHandler), so it must run
+	// after we've released it — same pattern as registerModel.
+	d.indexObserver(modelType, observer)
 	debugPrintf("✅ Observer %T attached to %s\n", observer, modelType.Name())
 }
 
+//
+// ✅ TYPED SUBSCRIBE/PUBLISH
+//
+// Subscribe and Publish are a type-safe alternative to Observer()/Notify
+// that skip MethodByName reflection entirely: handlers are matched by
+// Go type at registration time and invoked directly from Dispatcher.handlers.
+
+// Subscribe registers handler to run whenever Publish[T] fires event for a
+// payload of type T on d. The returned unsubscribe func removes the handler;
+// it is safe to defer and safe to call more than once.
+func Subscribe[T any](d *Dispatcher, event ObserverEventType, handler func(T)) (unsubscribe func()) {
+	var zero T
+	key := typeEventKey{payloadType: reflect.TypeOf(zero), event: event}
+	h := &genericHandler{fn: func(a any) { handler(a.(T)) }}
+
+	d.addHandler(key, h)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { d.removeHandler(key, h) })
+	}
+}
+
+// Publish dispatches payload to every handler subscribed to event for type T,
+// including Observer()-based observers that indexObserver translated.
+func Publish[T any](d *Dispatcher, event ObserverEventType, payload T) {
+	key := typeEventKey{payloadType: reflect.TypeOf(payload), event: event}
+
+	handlers := d.loadHandlers()[key]
+
+	for _, h := range handlers {
+		_ = d.invoke(context.Background(), event, payload, h)
+	}
+}
+
 //
 // ✅ GLOBAL HELPERS
 //
@@ -264,9 +702,11 @@ func Register(model any) {
 	Global().registerModel(model)
 }
 
-// ✅ Notify dispatches synchronously
-func Notify(event ObserverEventType, model any) {
-	Global().dispatchEvent(event, model)
+// ✅ Notify dispatches synchronously. For a "Before*" event, a non-nil
+// return means some observer vetoed the transition — see
+// Dispatcher.AttachWithPriority.
+func Notify(event ObserverEventType, model any) error {
+	return Global().dispatchEvent(event, model)
 }
 
 // ✅ NotifyAsync dispatches asynchronously
@@ -274,7 +714,34 @@ func NotifyAsync(event ObserverEventType, model any) {
 	Global().dispatchEventAsync(event, model)
 }
 
+// NotifyContext dispatches synchronously, in parallel, honoring ctx
+// cancellation and returning the joined error from every handler that
+// implements func(context.Context, T) error.
+func NotifyContext(ctx context.Context, event ObserverEventType, model any) error {
+	return Global().dispatchEventContext(ctx, event, model)
+}
+
 // Global helper for Attach
 func Attach(model any, observer any) {
 	Global().Attach(model, observer)
 }
+
+// Global helper for AttachWithPriority
+func AttachWithPriority(model any, event ObserverEventType, observer any, priority int) {
+	Global().AttachWithPriority(model, event, observer, priority)
+}
+
+// Global helper for AttachPattern
+func AttachPattern(model any, pattern ObserverEventType, observer PatternObserver) {
+	Global().AttachPattern(model, pattern, observer)
+}
+
+// Global helper for Emit
+func Emit(name string, payload any) {
+	Global().Emit(name, payload)
+}
+
+// Global helper for Use
+func Use(mw ...Middleware) {
+	Global().Use(mw...)
+}
@@ -1,10 +1,15 @@
 package observer
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 //
@@ -148,7 +153,520 @@ func TestDynamicEventType(t *testing.T) {
 
 //
 // ──────────────────────────────
-//   5️⃣ BENCHMARKS
+//   5️⃣ TYPED SUBSCRIBE/PUBLISH
+// ──────────────────────────────
+//
+
+func TestSubscribePublish(t *testing.T) {
+	d := NewDispatcher()
+	var got int32
+
+	unsubscribe := Subscribe(d, EventCreated, func(u TestUser) {
+		atomic.AddInt32(&got, int32(u.ID))
+	})
+
+	Publish(d, EventCreated, TestUser{ID: 5})
+	if atomic.LoadInt32(&got) != 5 {
+		t.Errorf("Expected handler to observe ID 5, got %d", got)
+	}
+
+	unsubscribe()
+	Publish(d, EventCreated, TestUser{ID: 5})
+	if atomic.LoadInt32(&got) != 5 {
+		t.Errorf("Expected no further calls after unsubscribe, got %d", got)
+	}
+}
+
+func TestSubscribeSeesObserverRegistrations(t *testing.T) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+
+	atomic.StoreInt32(&testLogger.createdCount, 0)
+	Publish(d, EventCreated, TestUser{ID: 1})
+
+	if atomic.LoadInt32(&testLogger.createdCount) != 1 {
+		t.Errorf("Expected Observer()-registered handler to be reachable via Publish, got %d", testLogger.createdCount)
+	}
+}
+
+//
+// ──────────────────────────────
+//   6️⃣ CONTEXT-AWARE NOTIFY
+// ──────────────────────────────
+//
+
+type ctxObserver struct {
+	calls int32
+	err   error
+}
+
+func (o *ctxObserver) BeforeCreate(ctx context.Context, u TestUser) error {
+	atomic.AddInt32(&o.calls, 1)
+	return o.err
+}
+
+func TestNotifyContextAggregatesErrors(t *testing.T) {
+	d := NewDispatcher()
+	boom := errors.New("boom")
+	o1 := &ctxObserver{err: boom}
+	o2 := &ctxObserver{}
+	d.addHandler(typeEventKey{payloadType: reflect.TypeOf(TestUser{}), event: EventBeforeCreate}, &genericHandler{
+		ctxFn: func(ctx context.Context, a any) error { return o1.BeforeCreate(ctx, a.(TestUser)) },
+	})
+	d.addHandler(typeEventKey{payloadType: reflect.TypeOf(TestUser{}), event: EventBeforeCreate}, &genericHandler{
+		ctxFn: func(ctx context.Context, a any) error { return o2.BeforeCreate(ctx, a.(TestUser)) },
+	})
+
+	err := d.dispatchEventContext(context.Background(), EventBeforeCreate, TestUser{ID: 1})
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected joined error to include %v, got %v", boom, err)
+	}
+	if atomic.LoadInt32(&o1.calls) != 1 || atomic.LoadInt32(&o2.calls) != 1 {
+		t.Errorf("Expected both handlers to run, got %d and %d", o1.calls, o2.calls)
+	}
+}
+
+func TestNotifyContextStopsSchedulingAfterCancel(t *testing.T) {
+	d := NewDispatcher()
+	o := &ctxObserver{}
+	d.addHandler(typeEventKey{payloadType: reflect.TypeOf(TestUser{}), event: EventBeforeCreate}, &genericHandler{
+		ctxFn: func(ctx context.Context, a any) error { return o.BeforeCreate(ctx, a.(TestUser)) },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := d.dispatchEventContext(ctx, EventBeforeCreate, TestUser{ID: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled in joined error, got %v", err)
+	}
+	if atomic.LoadInt32(&o.calls) != 0 {
+		t.Errorf("Expected no handlers to run once ctx was already canceled, got %d", o.calls)
+	}
+}
+
+//
+// ──────────────────────────────
+//   7️⃣ WILDCARD / HIERARCHICAL EVENTS
+// ──────────────────────────────
+//
+
+type recordingPatternObserver struct {
+	mu     sync.Mutex
+	events []ObserverEventType
+}
+
+func (r *recordingPatternObserver) HandleEvent(event ObserverEventType, model any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordingPatternObserver) seen() []ObserverEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ObserverEventType, len(r.events))
+	copy(out, r.events)
+	return out
+}
+
+func TestAttachPatternWildcardAndDoubleWildcard(t *testing.T) {
+	d := NewDispatcher()
+
+	single := &recordingPatternObserver{}
+	d.AttachPattern(TestUser{}, "user.*", single)
+
+	deep := &recordingPatternObserver{}
+	d.AttachPattern(TestUser{}, "user.**", deep)
+
+	global := &recordingPatternObserver{}
+	d.AttachPattern(TestUser{}, "**", global)
+
+	d.dispatchPattern("user.created", TestUser{ID: 1})
+	d.dispatchPattern("user.profile.updated", TestUser{ID: 1})
+
+	if got := single.seen(); len(got) != 1 || got[0] != "user.created" {
+		t.Errorf("Expected user.* to match only the single-segment event, got %v", got)
+	}
+	if got := deep.seen(); len(got) != 2 {
+		t.Errorf("Expected user.** to match both events, got %v", got)
+	}
+	if got := global.seen(); len(got) != 2 {
+		t.Errorf("Expected ** to match every event, got %v", got)
+	}
+}
+
+func TestEmitDoesNotRequireModelRegistration(t *testing.T) {
+	d := NewDispatcher()
+	obs := &recordingPatternObserver{}
+	d.AttachPattern(TestUser{}, "order.*", obs)
+
+	d.Emit("order.placed", TestUser{ID: 42})
+
+	if got := obs.seen(); len(got) != 1 || got[0] != "order.placed" {
+		t.Errorf("Expected Emit to reach the pattern subscriber without Register, got %v", got)
+	}
+}
+
+//
+// ──────────────────────────────
+//   8️⃣ MIDDLEWARE CHAIN
+// ──────────────────────────────
+//
+
+type noopBeforeCreateObserver struct{}
+
+func (noopBeforeCreateObserver) BeforeCreate(u TestUser) {}
+
+func TestUseWrapsHandlerInvocationOrder(t *testing.T) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+	d.Attach(TestUser{}, &noopBeforeCreateObserver{})
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, event ObserverEventType, model any) error {
+				order = append(order, name+":before")
+				err := next(ctx, event, model)
+				order = append(order, name+":after")
+				return err
+			}
+		}
+	}
+	d.Use(mark("outer"), mark("inner"))
+
+	_ = d.dispatchEventContext(context.Background(), EventBeforeCreate, TestUser{ID: 1})
+
+	want := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("Expected middleware order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Expected middleware order %v, got %v", want, order)
+			break
+		}
+	}
+}
+
+func TestUseRecoversFromPanickingHandler(t *testing.T) {
+	d := NewDispatcher()
+	d.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event ObserverEventType, model any) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("recovered: %v", r)
+				}
+			}()
+			return next(ctx, event, model)
+		}
+	})
+	d.addHandler(typeEventKey{payloadType: reflect.TypeOf(TestUser{}), event: EventBeforeCreate}, &genericHandler{
+		ctxFn: func(ctx context.Context, a any) error { panic("boom") },
+	})
+
+	err := d.dispatchEventContext(context.Background(), EventBeforeCreate, TestUser{ID: 1})
+	if err == nil {
+		t.Error("Expected the panic-recovering middleware to surface an error, got nil")
+	}
+}
+
+func recoverMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event ObserverEventType, model any) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("recovered: %v", r)
+				}
+			}()
+			return next(ctx, event, model)
+		}
+	}
+}
+
+func TestUseWrapsPublish(t *testing.T) {
+	d := NewDispatcher()
+	d.Use(recoverMiddleware())
+	Subscribe(d, EventCreated, func(u TestUser) { panic("boom") })
+
+	Publish(d, EventCreated, TestUser{ID: 1})
+	// Publish doesn't return an error; the assertion is that the panic
+	// above didn't escape and crash the test.
+}
+
+type panickingPatternObserver struct{}
+
+func (panickingPatternObserver) HandleEvent(event ObserverEventType, model any) { panic("boom") }
+
+func TestUseWrapsEmit(t *testing.T) {
+	d := NewDispatcher()
+	d.Use(recoverMiddleware())
+	d.AttachPattern(TestUser{}, "order.*", panickingPatternObserver{})
+
+	d.Emit("order.placed", TestUser{ID: 1})
+	// Emit doesn't return an error either; same assertion as above.
+}
+
+//
+// ──────────────────────────────
+//   9️⃣ PRIORITY / ABORTABLE EVENTS
+// ──────────────────────────────
+//
+
+type priorityRecorder struct {
+	name  string
+	order *[]string
+}
+
+func (p *priorityRecorder) BeforeCreate(u TestUser) {
+	*p.order = append(*p.order, p.name)
+}
+
+func TestAttachWithPriorityOrdersAscending(t *testing.T) {
+	d := NewDispatcher()
+
+	var order []string
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &priorityRecorder{name: "last", order: &order}, 10)
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &priorityRecorder{name: "first", order: &order}, -5)
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &priorityRecorder{name: "middle", order: &order}, 0)
+
+	if err := d.dispatchEvent(EventBeforeCreate, TestUser{ID: 1}); err != nil {
+		t.Fatalf("Expected no veto, got %v", err)
+	}
+
+	want := []string{"first", "middle", "last"}
+	if len(order) != len(want) {
+		t.Fatalf("Expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Expected order %v, got %v", want, order)
+			break
+		}
+	}
+}
+
+type vetoingValidator struct{}
+
+func (v *vetoingValidator) BeforeCreate(u TestUser) error {
+	return fmt.Errorf("name %q is not allowed", u.Name)
+}
+
+type neverCalledValidator struct {
+	called *bool
+}
+
+func (v *neverCalledValidator) BeforeCreate(u TestUser) {
+	*v.called = true
+}
+
+func TestAbortableBeforeEventStopsLaterHandlers(t *testing.T) {
+	d := NewDispatcher()
+
+	called := false
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &vetoingValidator{}, 0)
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &neverCalledValidator{called: &called}, 10)
+
+	err := d.dispatchEvent(EventBeforeCreate, TestUser{ID: 1, Name: "admin"})
+	if err == nil {
+		t.Fatal("Expected the vetoing validator's error to be returned, got nil")
+	}
+	if called {
+		t.Error("Expected the lower-priority handler to never run after the veto")
+	}
+}
+
+func TestAbortableBeforeEventStopsPatternSubscribers(t *testing.T) {
+	d := NewDispatcher()
+
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &vetoingValidator{}, 0)
+	obs := &recordingPatternObserver{}
+	d.AttachPattern(TestUser{}, "BeforeCreate", obs)
+
+	err := d.dispatchEvent(EventBeforeCreate, TestUser{ID: 1, Name: "admin"})
+	if err == nil {
+		t.Fatal("Expected the vetoing validator's error to be returned, got nil")
+	}
+	if got := obs.seen(); len(got) != 0 {
+		t.Errorf("Expected the pattern subscriber to never see a vetoed event, got %v", got)
+	}
+}
+
+type lifecycleVetoer struct{}
+
+func (lifecycleVetoer) BeforeCreate(ev *LifecycleEvent) {
+	if ev.Data().(TestUser).ID < 0 {
+		ev.SetAborted()
+	}
+}
+
+func TestLifecycleEventSetAbortedVetoes(t *testing.T) {
+	d := NewDispatcher()
+	called := false
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, lifecycleVetoer{}, 0)
+	d.AttachWithPriority(TestUser{}, EventBeforeCreate, &neverCalledValidator{called: &called}, 10)
+
+	err := d.dispatchEvent(EventBeforeCreate, TestUser{ID: -1})
+	if err == nil {
+		t.Fatal("Expected SetAborted to surface as an error from dispatchEvent, got nil")
+	}
+	if called {
+		t.Error("Expected the lower-priority handler to never run after SetAborted")
+	}
+}
+
+//
+// ──────────────────────────────
+//   11️⃣ RING-BUFFERED ASYNC
+// ──────────────────────────────
+//
+
+func TestSubscribeChanDeliversInOrder(t *testing.T) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+
+	ch, cancel := d.SubscribeChan(TestUser{}, EventCreated, 8)
+	defer cancel()
+
+	for i := 1; i <= 3; i++ {
+		d.dispatchEventAsync(EventCreated, TestUser{ID: i})
+	}
+
+	for i := 1; i <= 3; i++ {
+		ev := <-ch
+		if ev.Model.(TestUser).ID != i {
+			t.Errorf("Expected event %d to carry ID %d, got %d", i, i, ev.Model.(TestUser).ID)
+		}
+	}
+}
+
+func TestSubscribeChanDropOldestUnderLoad(t *testing.T) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+	d.ConfigureBackpressure(DropOldest, 0)
+
+	// No reader draining the channel: every publish must stay non-blocking.
+	_, cancel := d.SubscribeChan(TestUser{}, EventCreated, 2)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			d.dispatchEventAsync(EventCreated, TestUser{ID: i})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatchEventAsync blocked under DropOldest with a full, undrained buffer")
+	}
+}
+
+type slowAsyncObserver struct{ calls int32 }
+
+func (o *slowAsyncObserver) Created(u TestUser) {
+	atomic.AddInt32(&o.calls, 1)
+	time.Sleep(5 * time.Millisecond)
+}
+
+func TestDispatchEventAsyncBoundsGoroutinesPerHandler(t *testing.T) {
+	d := NewDispatcher()
+	d.ConfigureBackpressure(BlockPublisher, 0)
+	o := &slowAsyncObserver{}
+	d.Attach(TestUser{}, o)
+
+	const n = 50
+	before := runtime.NumGoroutine()
+	for i := 0; i < n; i++ {
+		d.dispatchEventAsync(EventCreated, TestUser{ID: i})
+	}
+	after := runtime.NumGoroutine()
+
+	// One handler means one worker ring, so at most a couple of extra
+	// goroutines regardless of how many events were just dispatched —
+	// unlike the old "go func()" per handler per event, which would have
+	// grown linearly with the loop above.
+	if grew := after - before; grew > 10 {
+		t.Errorf("Expected dispatchEventAsync to bound goroutine growth, went from %d to %d", before, after)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&o.calls) < n && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&o.calls); got != n {
+		t.Errorf("Expected all %d dispatched events to eventually reach the handler, got %d", n, got)
+	}
+}
+
+func TestRemoveHandlerCancelsItsAsyncWorker(t *testing.T) {
+	d := NewDispatcher()
+	d.ConfigureBackpressure(BlockPublisher, 0)
+
+	for i := 0; i < 20; i++ {
+		unsubscribe := Subscribe(d, EventCreated, func(u TestUser) {})
+		d.dispatchEventAsync(EventCreated, TestUser{ID: i})
+		unsubscribe()
+	}
+
+	d.mu.Lock()
+	got := len(d.asyncWorkers)
+	d.mu.Unlock()
+	if got != 0 {
+		t.Errorf("Expected unsubscribe to release its asyncHandlerRing worker, got %d still cached", got)
+	}
+}
+
+func TestDispatchEventAsyncRecoversFromUnsubscribedWorker(t *testing.T) {
+	d := NewDispatcher()
+	d.ConfigureBackpressure(UnsubscribeOnOverflow, 0)
+
+	var got int32
+	unsubscribe := Subscribe(d, EventCreated, func(u TestUser) {
+		atomic.AddInt32(&got, 1)
+		time.Sleep(time.Millisecond) // slow enough that pushes outrun consumption
+	})
+	defer unsubscribe()
+
+	// Flood the ring faster than the slow handler above can drain it,
+	// forcing UnsubscribeOnOverflow to close it out from under
+	// dispatchEventAsync.
+	for i := 0; i < defaultAsyncHandlerBufSize*4; i++ {
+		d.dispatchEventAsync(EventCreated, TestUser{ID: i})
+	}
+
+	// Wait until delivery stops: once the ring has unsubscribed itself,
+	// run returns and discards whatever it still had buffered. What
+	// follows then only measures events dispatched after the close.
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		before := atomic.LoadInt32(&got)
+		time.Sleep(50 * time.Millisecond)
+		if atomic.LoadInt32(&got) == before || time.Now().After(deadline) {
+			break
+		}
+	}
+	drained := atomic.LoadInt32(&got)
+
+	// A later dispatch must get a fresh ring instead of being silently
+	// and permanently dropped by the now-closed cached one.
+	d.dispatchEventAsync(EventCreated, TestUser{ID: -1})
+
+	deadline = time.Now().Add(time.Second)
+	for atomic.LoadInt32(&got) == drained && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if atomic.LoadInt32(&got) == drained {
+		t.Error("Expected dispatchEventAsync to recover with a fresh ring after the cached one unsubscribed itself")
+	}
+}
+
+//
+// ──────────────────────────────
+//   12️⃣ BENCHMARKS
 // ──────────────────────────────
 //
 
@@ -177,6 +695,25 @@ func BenchmarkNotifyAsync(b *testing.B) {
 		NotifyAsync(EventCreated, u)
 	}
 }
+func BenchmarkNotifyAsyncRingBuffered(b *testing.B) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+
+	ch, cancel := d.SubscribeChan(TestUser{}, EventCreated, 1024)
+	defer cancel()
+	go func() {
+		for range ch {
+		}
+	}()
+
+	u := TestUser{ID: 1, Name: "Benchmark"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.dispatchEventAsync(EventCreated, u)
+	}
+}
+
 func BenchmarkNotifyParallel(b *testing.B) {
 	Register(TestUser{})
 
@@ -198,3 +735,37 @@ func BenchmarkNotifyAsyncParallel(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkNotifyParallelUnderChurn dispatches concurrently while a
+// background goroutine keeps attaching a fresh handler for an unrelated
+// event, which keeps addHandler swapping in a new handlersPtr the whole
+// time. dispatchEvent looks its handlers up with a single load of that
+// pointer, but each handler invocation still read-locks d.mu in chain, so
+// dispatch can wait behind addHandler while it holds that lock.
+func BenchmarkNotifyParallelUnderChurn(b *testing.B) {
+	d := NewDispatcher()
+	d.registerModel(TestUser{})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		var order []string
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			d.AttachWithPriority(TestUser{}, EventBeforeCreate, &priorityRecorder{name: "churn", order: &order}, i)
+		}
+	}()
+
+	u := TestUser{ID: 1, Name: "Benchmark"}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			d.dispatchEvent(EventCreated, u)
+		}
+	})
+}
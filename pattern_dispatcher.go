@@ -0,0 +1,127 @@
+package observer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// PatternObserver handles every event whose dot-separated name matches a
+// pattern registered via Dispatcher.AttachPattern, regardless of how many
+// concrete event names that pattern expands to.
+type PatternObserver interface {
+	HandleEvent(event ObserverEventType, model any)
+}
+
+// patternHandler is a PatternObserver bound to a node in the pattern trie,
+// optionally scoped to a single model type.
+type patternHandler struct {
+	modelType reflect.Type
+	fn        func(event ObserverEventType, model any)
+}
+
+// patternNode is one dot-separated segment of a pattern trie. "*" children
+// match exactly one segment; "**" children match any number of remaining
+// segments (including zero) and stop descending further.
+type patternNode struct {
+	children map[string]*patternNode
+	handlers []*patternHandler
+}
+
+func newPatternNode() *patternNode {
+	return &patternNode{children: make(map[string]*patternNode)}
+}
+
+// AttachPattern registers observer to run for every event whose name
+// matches pattern, where pattern segments are dot-separated and may use "*"
+// to match exactly one segment or "**" to match any number of trailing
+// segments. For example "user.*" matches "user.created" but not
+// "user.profile.updated", while "user.**" matches both. observer only
+// fires for events carrying a payload of model's type.
+func (d *Dispatcher) AttachPattern(model any, pattern ObserverEventType, observer PatternObserver) {
+	modelType := normalizeModelType(model)
+
+	d.patternMu.Lock()
+	defer d.patternMu.Unlock()
+	if d.patternRoot == nil {
+		d.patternRoot = newPatternNode()
+	}
+
+	node := d.patternRoot
+	for _, seg := range strings.Split(string(pattern), ".") {
+		child, ok := node.children[seg]
+		if !ok {
+			child = newPatternNode()
+			node.children[seg] = child
+		}
+		node = child
+	}
+	node.handlers = append(node.handlers, &patternHandler{
+		modelType: modelType,
+		fn:        observer.HandleEvent,
+	})
+}
+
+// Emit dispatches payload to every pattern subscriber whose pattern matches
+// name, without requiring name or reflect.TypeOf(payload) to have been
+// registered up front — a general in-process event bus on top of the same
+// pattern trie Notify consults for dot-separated event names.
+func (d *Dispatcher) Emit(name string, payload any) {
+	d.dispatchPattern(ObserverEventType(name), payload)
+}
+
+func (d *Dispatcher) dispatchPattern(event ObserverEventType, model any) {
+	d.patternMu.RLock()
+	root := d.patternRoot
+	d.patternMu.RUnlock()
+	if root == nil {
+		return
+	}
+
+	segs := strings.Split(string(event), ".")
+	var matched []*patternHandler
+
+	d.patternMu.RLock()
+	collectPatternMatches(segs, root, &matched)
+	d.patternMu.RUnlock()
+
+	if len(matched) == 0 {
+		return
+	}
+
+	modelType := normalizeModelType(model)
+	for _, h := range matched {
+		if h.modelType != modelType {
+			continue
+		}
+		// Route through invoke/chain like every other dispatch path, so
+		// Dispatcher.Use middleware (Recover, tracing, metrics, ...) wraps
+		// pattern subscribers too.
+		h := h
+		gh := &genericHandler{fn: func(a any) { h.fn(event, a) }}
+		_ = d.invoke(context.Background(), event, model, gh)
+	}
+}
+
+// collectPatternMatches walks segs down the trie rooted at node, appending
+// every handler reachable via an exact match, a "*" wildcard, or a "**"
+// wildcard (which absorbs all remaining segments).
+func collectPatternMatches(segs []string, node *patternNode, out *[]*patternHandler) {
+	if node == nil {
+		return
+	}
+	if wild, ok := node.children["**"]; ok {
+		*out = append(*out, wild.handlers...)
+	}
+	if len(segs) == 0 {
+		*out = append(*out, node.handlers...)
+		return
+	}
+	head, rest := segs[0], segs[1:]
+	if child, ok := node.children[head]; ok {
+		collectPatternMatches(rest, child, out)
+	}
+	if child, ok := node.children["*"]; ok {
+		collectPatternMatches(rest, child, out)
+	}
+}